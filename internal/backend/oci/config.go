@@ -7,6 +7,7 @@ import (
 	"os"
 	"path"
 	"strings"
+	"time"
 
 	"github.com/oracle/oci-go-sdk/v65/common"
 	"github.com/oracle/oci-go-sdk/v65/common/auth"
@@ -25,6 +26,28 @@ const (
 	InstancePrincipal OraclePrincipalType = "InstancePrincipal"
 	// WorkloadPrincipal represents a workload principal.
 	WorkloadPrincipal OraclePrincipalType = "workload"
+	// SessionToken represents authentication via an OCI CLI session token,
+	// as produced by `oci session authenticate`.
+	SessionToken OraclePrincipalType = "session_token"
+	// ConfigFile represents authentication via an existing OCI config file
+	// and profile, without restating tenancy/user/fingerprint/key.
+	ConfigFile OraclePrincipalType = "config_file"
+	// ResourcePrincipal represents authentication via a generic OCI resource
+	// principal (e.g. an OCI Function), as opposed to the OKE-specific
+	// workload identity handled by WorkloadPrincipal.
+	ResourcePrincipal OraclePrincipalType = "resource_principal"
+	// ParPrincipal represents read-only access through an OCI
+	// Pre-Authenticated Request URL: the URL itself carries the
+	// authorization, so none of the usual tenancy/user/key fields are used.
+	ParPrincipal OraclePrincipalType = "par"
+)
+
+// Storage tier names accepted by the storage-tier option. These mirror the
+// objectstorage.StorageTier enum used on PutObject/UploadStreamRequest.
+const (
+	StorageTierStandard         = "Standard"
+	StorageTierInfrequentAccess = "InfrequentAccess"
+	StorageTierArchive          = "Archive"
 )
 
 const (
@@ -40,6 +63,19 @@ const (
 	UserPrincipalKey        = "user_principal"
 	InstancePrincipalKey    = "instance_principal"
 	WorkloadKey             = "workload"
+	SessionTokenKey         = "session_token"
+	ConfigFileKey           = "config_file"
+	ResourcePrincipalKey    = "resource_principal"
+
+	OCI_SSE_CUSTOMER_ALGORITHM_ENV_VAR  = "OCI_SSE_CUSTOMER_ALGORITHM"
+	OCI_SSE_CUSTOMER_KEY_ENV_VAR        = "OCI_SSE_CUSTOMER_KEY"
+	OCI_SSE_CUSTOMER_KEY_SHA256_ENV_VAR = "OCI_SSE_CUSTOMER_KEY_SHA256"
+	OCI_SSE_KMS_KEY_ID_ENV_VAR          = "OCI_SSE_KMS_KEY_ID"
+
+	OCI_CONFIG_FILE_ENV_VAR = "OCI_CONFIG_FILE"
+	OCI_PROFILE_ENV_VAR     = "OCI_PROFILE"
+
+	OCI_STORAGE_TIER_ENV_VAR = "RESTIC_OCI_STORAGE_TIER"
 )
 
 // Config holds the configuration required for communicating with the OCI
@@ -56,12 +92,82 @@ type Config struct {
 	Prefix          string
 	CompartmentOCID string
 	Connections     uint `option:"connections" help:"set a limit for the number of concurrent connections (default: 5)"`
+
+	// UploadPartSize and UploadConcurrency tune the multipart upload that
+	// transfer.UploadManager performs once an object is large enough to
+	// need one; mirrors the tuning knobs on the s3 backend.
+	UploadPartSize    int64 `option:"upload-part-size" help:"size in bytes of each part of a multipart upload (default: 128 MiB)"`
+	UploadConcurrency int   `option:"upload-concurrency" help:"number of parts to upload concurrently for a multipart upload (default: 5)"`
+
+	// ConfigFilePath and Profile are used by the SessionToken and
+	// ConfigFile auth types to locate the OCI CLI config file and the
+	// profile within it to read credentials from.
+	ConfigFilePath string
+	Profile        string
+
+	// SseCustomerAlgorithm, SseCustomerKey and SseCustomerKeySha256 enable
+	// customer-provided encryption keys (SSE-C). SseCustomerKey holds the
+	// raw 32-byte key, base64-encoded.
+	SseCustomerAlgorithm string               `option:"sse-customer-algorithm" help:"enable SSE-C using the given algorithm, e.g. AES256"`
+	SseCustomerKey       options.SecretString `option:"sse-customer-key" help:"base64-encoded 32-byte key used for SSE-C"`
+	SseCustomerKeySha256 string               `option:"sse-customer-key-sha256" help:"base64-encoded SHA256 digest of the SSE-C key"`
+
+	// SseKmsKeyID requests OCI Vault-managed (KMS) encryption for objects
+	// written by this backend.
+	SseKmsKeyID string `option:"sse-kms-key-id" help:"OCID of the OCI Vault master encryption key to use for server-side encryption"`
+
+	// StorageTier selects the OCI storage tier new objects are written with
+	// (Standard, InfrequentAccess or Archive). Archive-tier objects must be
+	// restored before they can be read back, see Backend.ensureRestored.
+	StorageTier string `option:"storage-tier" help:"storage tier for new objects: Standard, InfrequentAccess or Archive (default: Standard)"`
+
+	// ArchiveRestoreHours is the number of hours an Archive-tier object
+	// stays restored for once a restore completes.
+	ArchiveRestoreHours int `option:"archive-restore-hours" help:"number of hours a restored archive object stays available (default: 24)"`
+
+	// ArchiveRestoreTimeout bounds how long Load waits for an Archive-tier
+	// object to finish restoring before giving up.
+	ArchiveRestoreTimeout time.Duration `option:"archive-restore-timeout" help:"how long to wait for an archived object to be restored (default: 12h)"`
+
+	// ArchiveRestoreWait controls what Load does when it finds an
+	// Archive-tier object: if true (the default) it kicks off a restore and
+	// blocks until the object becomes available or ArchiveRestoreTimeout
+	// elapses; if false it kicks off the restore and immediately returns
+	// ErrObjectArchived, letting the caller retry later instead of blocking.
+	ArchiveRestoreWait bool `option:"archive-restore-wait" help:"block in Load until an archived object is restored, instead of failing immediately (default: true)"`
+
+	// RetryMaxElapsed and RetryMaxAttempts bound how long/how often the
+	// transport retries throttled or transient requests against OCI.
+	RetryMaxElapsed  time.Duration `option:"retry-max-elapsed" help:"give up retrying a request after this much time has elapsed (default: 5m)"`
+	RetryMaxAttempts int           `option:"retry-max-attempts" help:"give up retrying a request after this many attempts (default: 10)"`
+
+	// The following options only take effect when restic creates the
+	// bucket itself (restic init), via ensureBucketExists/createBucket.
+	BucketVersioning       string `option:"bucket-versioning" help:"enable object versioning on a newly created bucket: Enabled or Disabled"`
+	BucketAutoTiering      string `option:"bucket-auto-tiering" help:"enable automatic tiering on a newly created bucket: InfrequentAccess"`
+	BucketKmsKeyID         string `option:"bucket-kms-key-id" help:"OCID of the OCI Vault master encryption key used to encrypt a newly created bucket"`
+	BucketPublicAccessType string `option:"bucket-public-access-type" help:"public access type for a newly created bucket (default: NoPublicAccess)"`
+
+	// ParURL is set when the repository string has the form
+	// "oci:par:<par-url>". It holds a full OCI Pre-Authenticated Request
+	// URL and is the only field needed to read a repository through it; see
+	// Open and the parBackend it constructs when OCIAuthType is
+	// ParPrincipal.
+	ParURL string
 }
 
 // NewConfig returns a new Config with the default values filled in.
 func NewConfig() Config {
 	return Config{
-		Connections: 5,
+		Connections:           5,
+		UploadPartSize:        128 * 1024 * 1024,
+		UploadConcurrency:     5,
+		StorageTier:           StorageTierStandard,
+		ArchiveRestoreHours:   24,
+		ArchiveRestoreTimeout: 12 * time.Hour,
+		ArchiveRestoreWait:    true,
+		RetryMaxElapsed:       5 * time.Minute,
+		RetryMaxAttempts:      10,
 	}
 }
 
@@ -72,6 +178,10 @@ func NewConfig() Config {
 // "oci:bucket-name"
 // "oci:bucket-name/test1"
 // "oci:bucket-name/test1/test2"
+//
+// A third form, "oci:par:<par-url>", configures a read-only backend backed
+// entirely by an OCI Pre-Authenticated Request URL, with no other
+// credentials required; see ParPrincipal.
 func ParseConfig(s string) (*Config, error) {
 	if !strings.HasPrefix(s, "oci:") {
 		return nil, errors.New("oci: invalid format")
@@ -80,6 +190,16 @@ func ParseConfig(s string) (*Config, error) {
 	// strip prefix "oci:"
 	s = s[4:]
 
+	if rest, ok := strings.CutPrefix(s, "par:"); ok {
+		if rest == "" {
+			return nil, errors.New("oci: par: URL is empty")
+		}
+		cfg := NewConfig()
+		cfg.OCIAuthType = ParPrincipal
+		cfg.ParURL = rest
+		return &cfg, nil
+	}
+
 	// use the first entry of the path as the bucket name and the
 	// remainder as prefix
 	bucketName, prefix, _ := strings.Cut(s, "/")
@@ -91,6 +211,21 @@ func ParseConfig(s string) (*Config, error) {
 
 }
 
+// objectNamePrefix returns the literal prefix that Filename actually joins
+// onto object names for a repository configured with the given cfg.Prefix.
+// ParseConfig defaults an empty prefix to ".", but path.Join (used by
+// layout.DefaultLayout, which backs Filename) cleans that "." away, so real
+// object names never start with it - callers that need to scope access to
+// exactly the objects this repository owns (e.g. MintPAR) must use this
+// instead of cfg.Prefix directly.
+func objectNamePrefix(prefix string) string {
+	prefix = strings.TrimSuffix(prefix, "/")
+	if prefix == "" || prefix == "." {
+		return ""
+	}
+	return prefix + "/"
+}
+
 var _ restic.ApplyEnvironmenter = &Config{}
 
 // ApplyEnvironment saves values from the environment to the config.
@@ -110,6 +245,27 @@ func (cfg *Config) ApplyEnvironment(prefix string) {
 			cfg.Region = os.Getenv(prefix + OCI_REGION_ENV_VAR)
 		}
 
+	case ResourcePrincipalKey:
+		cfg.OCIAuthType = ResourcePrincipal
+
+	case SessionTokenKey:
+		cfg.OCIAuthType = SessionToken
+		if cfg.ConfigFilePath == "" {
+			cfg.ConfigFilePath = os.Getenv(prefix + OCI_CONFIG_FILE_ENV_VAR)
+		}
+		if cfg.Profile == "" {
+			cfg.Profile = os.Getenv(prefix + OCI_PROFILE_ENV_VAR)
+		}
+
+	case ConfigFileKey:
+		cfg.OCIAuthType = ConfigFile
+		if cfg.ConfigFilePath == "" {
+			cfg.ConfigFilePath = os.Getenv(prefix + OCI_CONFIG_FILE_ENV_VAR)
+		}
+		if cfg.Profile == "" {
+			cfg.Profile = os.Getenv(prefix + OCI_PROFILE_ENV_VAR)
+		}
+
 	default:
 		cfg.OCIAuthType = UserPrincipal
 		if cfg.Region == "" {
@@ -139,6 +295,41 @@ func (cfg *Config) ApplyEnvironment(prefix string) {
 
 	}
 
+	if cfg.SseCustomerAlgorithm == "" {
+		cfg.SseCustomerAlgorithm = os.Getenv(prefix + OCI_SSE_CUSTOMER_ALGORITHM_ENV_VAR)
+	}
+	if key, ok := os.LookupEnv(prefix + OCI_SSE_CUSTOMER_KEY_ENV_VAR); ok {
+		cfg.SseCustomerKey = options.NewSecretString(key)
+	}
+	if cfg.SseCustomerKeySha256 == "" {
+		cfg.SseCustomerKeySha256 = os.Getenv(prefix + OCI_SSE_CUSTOMER_KEY_SHA256_ENV_VAR)
+	}
+	if cfg.SseKmsKeyID == "" {
+		cfg.SseKmsKeyID = os.Getenv(prefix + OCI_SSE_KMS_KEY_ID_ENV_VAR)
+	}
+
+	if tier := os.Getenv(prefix + OCI_STORAGE_TIER_ENV_VAR); tier != "" {
+		cfg.StorageTier = normalizeStorageTier(tier)
+	}
+}
+
+// normalizeStorageTier maps the lower_snake_case tier names used by the
+// RESTIC_OCI_STORAGE_TIER environment variable (standard,
+// infrequent_access, archive) onto the mixed-case values the OCI API
+// expects. Values that already match the API's casing, or that don't match
+// any known alias, are passed through unchanged so cfg.StorageTier can be
+// sent to OCI as-is.
+func normalizeStorageTier(tier string) string {
+	switch strings.ToLower(tier) {
+	case "standard":
+		return StorageTierStandard
+	case "infrequent_access", "infrequentaccess":
+		return StorageTierInfrequentAccess
+	case "archive":
+		return StorageTierArchive
+	default:
+		return tier
+	}
 }
 
 // NewConfigurationProvider build the OCI Auth provider
@@ -148,6 +339,12 @@ func NewConfigurationProvider(cfg *Config) (common.ConfigurationProvider, error)
 		return auth.InstancePrincipalConfigurationProvider()
 	case WorkloadPrincipal:
 		return auth.OkeWorkloadIdentityConfigurationProvider()
+	case ResourcePrincipal:
+		return auth.ResourcePrincipalConfigurationProvider()
+	case SessionToken:
+		return auth.NewSessionTokenProvider(cfg.ConfigFilePath, cfg.Profile)
+	case ConfigFile:
+		return common.CustomProfileConfigProvider(cfg.ConfigFilePath, cfg.Profile), nil
 	}
 	// This is default case - UserPrincipal
 	return NewConfigurationProviderWithUserPrincipal(cfg)
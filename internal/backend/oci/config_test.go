@@ -1,33 +1,99 @@
 package oci
 
 import (
-	"github.com/restic/restic/internal/backend/test"
 	"testing"
+	"time"
+
+	"github.com/restic/restic/internal/backend/test"
 )
 
 var configTests = []test.ConfigTestData[Config]{
 	{S: "oci:ocitest", Cfg: Config{
-		BucketName:  "ocitest",
-		Prefix:      ".",
-		Connections: 5,
+		BucketName:            "ocitest",
+		Prefix:                ".",
+		Connections:           5,
+		UploadPartSize:        128 * 1024 * 1024,
+		UploadConcurrency:     5,
+		StorageTier:           StorageTierStandard,
+		ArchiveRestoreHours:   24,
+		ArchiveRestoreTimeout: 12 * time.Hour,
+		ArchiveRestoreWait:    true,
+		RetryMaxElapsed:       5 * time.Minute,
+		RetryMaxAttempts:      10,
 	}},
 	{S: "oci:ocitest/", Cfg: Config{
-		BucketName:  "ocitest",
-		Prefix:      ".",
-		Connections: 5,
+		BucketName:            "ocitest",
+		Prefix:                ".",
+		Connections:           5,
+		UploadPartSize:        128 * 1024 * 1024,
+		UploadConcurrency:     5,
+		StorageTier:           StorageTierStandard,
+		ArchiveRestoreHours:   24,
+		ArchiveRestoreTimeout: 12 * time.Hour,
+		ArchiveRestoreWait:    true,
+		RetryMaxElapsed:       5 * time.Minute,
+		RetryMaxAttempts:      10,
 	}},
 	{S: "oci:ocitest/prefix/directory", Cfg: Config{
-		BucketName:  "ocitest",
-		Prefix:      "prefix/directory",
-		Connections: 5,
+		BucketName:            "ocitest",
+		Prefix:                "prefix/directory",
+		Connections:           5,
+		UploadPartSize:        128 * 1024 * 1024,
+		UploadConcurrency:     5,
+		StorageTier:           StorageTierStandard,
+		ArchiveRestoreHours:   24,
+		ArchiveRestoreTimeout: 12 * time.Hour,
+		ArchiveRestoreWait:    true,
+		RetryMaxElapsed:       5 * time.Minute,
+		RetryMaxAttempts:      10,
 	}},
 	{S: "oci:ocitest/prefix/directory/", Cfg: Config{
-		BucketName:  "ocitest",
-		Prefix:      "prefix/directory",
-		Connections: 5,
+		BucketName:            "ocitest",
+		Prefix:                "prefix/directory",
+		Connections:           5,
+		UploadPartSize:        128 * 1024 * 1024,
+		UploadConcurrency:     5,
+		StorageTier:           StorageTierStandard,
+		ArchiveRestoreHours:   24,
+		ArchiveRestoreTimeout: 12 * time.Hour,
+		ArchiveRestoreWait:    true,
+		RetryMaxElapsed:       5 * time.Minute,
+		RetryMaxAttempts:      10,
+	}},
+	{S: "oci:par:https://objectstorage.us-phoenix-1.oraclecloud.com/p/token/n/namespace/b/ocitest/o/", Cfg: Config{
+		OCIAuthType:           ParPrincipal,
+		ParURL:                "https://objectstorage.us-phoenix-1.oraclecloud.com/p/token/n/namespace/b/ocitest/o/",
+		Connections:           5,
+		UploadPartSize:        128 * 1024 * 1024,
+		UploadConcurrency:     5,
+		StorageTier:           StorageTierStandard,
+		ArchiveRestoreHours:   24,
+		ArchiveRestoreTimeout: 12 * time.Hour,
+		ArchiveRestoreWait:    true,
+		RetryMaxElapsed:       5 * time.Minute,
+		RetryMaxAttempts:      10,
 	}},
 }
 
 func TestParseConfig(t *testing.T) {
 	test.ParseConfigTester(t, ParseConfig, configTests)
 }
+
+func TestObjectNamePrefix(t *testing.T) {
+	var tests = []struct {
+		prefix string
+		want   string
+	}{
+		{"", ""},
+		{".", ""},
+		{"restic", "restic/"},
+		{"restic/", "restic/"},
+		{"prefix/directory", "prefix/directory/"},
+	}
+
+	for _, test := range tests {
+		if got := objectNamePrefix(test.prefix); got != test.want {
+			t.Errorf("objectNamePrefix(%q) = %q, want %q", test.prefix, got, test.want)
+		}
+	}
+}
@@ -0,0 +1,56 @@
+package oci
+
+import (
+	"encoding/base64"
+
+	"github.com/oracle/oci-go-sdk/v65/common"
+	"github.com/oracle/oci-go-sdk/v65/objectstorage"
+	"github.com/oracle/oci-go-sdk/v65/objectstorage/transfer"
+	"github.com/restic/restic/internal/restic"
+)
+
+// digester is implemented by restic.RewindReader: it exposes the digest
+// accumulated while restic hashed the data with the hash.Hash returned by
+// Backend.Hasher, so Save can hand it to OCI as ContentMD5 without a second
+// pass over the data.
+type digester interface {
+	Hash() []byte
+}
+
+// applyContentMD5 sets req.ContentMD5 from rd's already-computed digest, so
+// OCI rejects the upload if the body was corrupted in transit rather than
+// silently accepting it.
+func (be *Backend) applyContentMD5(req *transfer.UploadRequest, rd restic.RewindReader) {
+	d, ok := rd.(digester)
+	if !ok {
+		return
+	}
+	sum := d.Hash()
+	if len(sum) == 0 {
+		return
+	}
+	req.ContentMD5 = common.String(base64.StdEncoding.EncodeToString(sum))
+}
+
+// applyConditionalToUpload makes Save an opportunistic conditional request:
+// if we have previously observed an ETag for h (from Stat or List), require
+// the object to still match it, so a concurrent writer is surfaced as a
+// precondition failure instead of a silent overwrite. With no cached ETag
+// (the common case: a first-time write, or a retry of one whose response
+// never arrived) the request is left unconditional, since restic's
+// content-addressed filenames mean a same-name object is the same content,
+// and a lost-response retry must be able to succeed against what it already
+// wrote.
+func (be *Backend) applyConditionalToUpload(req *transfer.UploadRequest, h restic.Handle) {
+	if etag, ok := be.etags.Load(h); ok {
+		req.IfMatch = common.String(etag.(string))
+	}
+}
+
+// cacheListETag records the ETag reported by ListObjects for h so that a
+// later Save/Remove of the same handle can make a conditional request.
+func (be *Backend) cacheListETag(h restic.Handle, obj objectstorage.ObjectSummary) {
+	if etag := SafeDeref[string](obj.ETag); etag != "" {
+		be.etags.Store(h, etag)
+	}
+}
@@ -2,6 +2,7 @@ package oci
 
 import (
 	"context"
+	"crypto/md5"
 	"fmt"
 	"github.com/oracle/oci-go-sdk/v65/common"
 	"github.com/oracle/oci-go-sdk/v65/common/auth"
@@ -21,6 +22,8 @@ import (
 	"path"
 	"path/filepath"
 	"strings"
+	"sync"
+	"time"
 )
 
 // Backend stores data on an OCI object store.
@@ -29,11 +32,27 @@ type Backend struct {
 	cfg         Config
 	connections uint
 	layout.Layout
+
+	// etags caches the last-observed ETag for a handle, populated by Stat
+	// and List. Save and Remove use it to make an opportunistic conditional
+	// request so that a write which raced with another process is detected
+	// as a precondition failure instead of silently clobbering data.
+	etags sync.Map // restic.Handle -> string
 }
 
 // make sure that *Backend implements backend.Backend
 var _ restic.Backend = &Backend{}
 
+// ErrObjectArchived is returned by Load when it finds an Archive-tier object
+// and cfg.ArchiveRestoreWait is false: a restore has been kicked off, but the
+// object isn't readable yet. Callers should retry after giving the restore
+// time to complete rather than treat this as a permanent failure.
+//
+// This belongs on backend.ErrObjectArchived so every backend that supports
+// cold storage tiers can surface it the same way; it lives here until that
+// type exists upstream.
+var ErrObjectArchived = errors.New("object is archived and must be restored before it can be read")
+
 func NewFactory() location.Factory {
 	return location.NewHTTPBackendFactory("oci", ParseConfig, location.NoPassword, Create, Open)
 }
@@ -59,6 +78,18 @@ func open(cfg Config, rt http.RoundTripper) (*Backend, error) {
 			return nil, errors.Fatalf("unable to set OCI SDK environment variable: %s\n", auth.ResourcePrincipalRegionEnvVar)
 		}
 
+	case ResourcePrincipal:
+		// no extra setup needed: the OCI Functions runtime already
+		// populates the resource principal environment variables.
+
+	case SessionToken, ConfigFile:
+		if cfg.ConfigFilePath == "" {
+			return nil, errors.Fatalf("unable to authenticate OCI object store: config file path ($OCI_CONFIG_FILE) is empty")
+		}
+		if cfg.Profile == "" {
+			return nil, errors.Fatalf("unable to authenticate OCI object store: profile name ($OCI_PROFILE) is empty")
+		}
+
 	case UserPrincipal:
 		if cfg.Region == "" {
 			return nil, errors.Fatalf("unable to authenticate OCI object store: Tenancy ID ($OCI_REGION) is empty")
@@ -101,7 +132,7 @@ func open(cfg Config, rt http.RoundTripper) (*Backend, error) {
 		debug.Log("Error %v", err)
 		return nil, err
 	}
-	c.HTTPClient = &http.Client{Transport: rt}
+	c.HTTPClient = &http.Client{Transport: newRetryTransport(rt, cfg.RetryMaxElapsed, cfg.RetryMaxAttempts)}
 
 	be := &Backend{
 		client:      c,
@@ -116,8 +147,13 @@ func open(cfg Config, rt http.RoundTripper) (*Backend, error) {
 }
 
 // Open opens the OCI backend at bucket and region. The bucket is created if it
-// does not exist yet.
+// does not exist yet. If cfg was parsed from an "oci:par:<url>" config
+// string, it instead opens a read-only backend backed by that
+// Pre-Authenticated Request URL, without needing any other credentials.
 func Open(_ context.Context, cfg Config, rt http.RoundTripper) (restic.Backend, error) {
+	if cfg.OCIAuthType == ParPrincipal {
+		return openPAR(cfg, rt)
+	}
 	return open(cfg, rt)
 }
 
@@ -134,7 +170,7 @@ func Create(ctx context.Context, cfg Config, rt http.RoundTripper) (restic.Backe
 		return nil, err
 	}
 
-	err = ensureBucketExists(ctx, be.client, ociNamespace, cfg.BucketName, cfg.CompartmentOCID)
+	err = ensureBucketExists(ctx, be.client, ociNamespace, cfg.BucketName, cfg)
 	if err != nil {
 		return nil, err
 	}
@@ -163,7 +199,7 @@ func (be *Backend) Location() string {
 
 // Hasher may return a hash function for calculating a content hash for the backend
 func (be *Backend) Hasher() hash.Hash {
-	return nil
+	return md5.New()
 }
 
 // HasAtomicReplace returns whether Save() can atomically replace files
@@ -193,20 +229,28 @@ func (be *Backend) Save(ctx context.Context, h restic.Handle, rd restic.RewindRe
 			EnableMultipartChecksumVerification: common.Bool(true),
 			AllowMultipartUploads:               common.Bool(true),
 			AllowParrallelUploads:               common.Bool(true),
+			PartSize:                            common.Int64(be.cfg.UploadPartSize),
+			NumberOfGoroutines:                  common.Int(be.cfg.UploadConcurrency),
 			ObjectStorageClient:                 &be.client,
 			ContentType:                         common.String(ContentType),
+			StorageTier:                         objectstorage.StorageTierEnum(be.cfg.StorageTier),
 		},
 		StreamReader: io.NopCloser(rd),
 	}
-	_, err = uploadManager.UploadStream(ctx, req)
+	be.applySseToUpload(&req.UploadRequest)
+	be.applyContentMD5(&req.UploadRequest, rd)
+	be.applyConditionalToUpload(&req.UploadRequest, h)
+	err = be.uploadStreamWithRetry(ctx, uploadManager, req, rd)
 
 	// sanity check
 	if err == nil {
-		getObjectDetails, err := be.client.HeadObject(ctx, objectstorage.HeadObjectRequest{
+		headReq := objectstorage.HeadObjectRequest{
 			NamespaceName: common.String(ociNamespace),
 			BucketName:    common.String(be.cfg.BucketName),
 			ObjectName:    common.String(objName),
-		})
+		}
+		be.applySseToHead(&headReq)
+		getObjectDetails, err := be.client.HeadObject(ctx, headReq)
 		if err != nil {
 			return errors.Wrap(err, "client.fetch getResponse")
 		}
@@ -214,6 +258,9 @@ func (be *Backend) Save(ctx context.Context, h restic.Handle, rd restic.RewindRe
 		if size != rd.Length() {
 			return errors.Errorf("wrote %d bytes instead of the expected %d bytes", size, rd.Length())
 		}
+		if etag := SafeDeref[string](getObjectDetails.ETag); etag != "" {
+			be.etags.Store(h, etag)
+		}
 	}
 	return errors.Wrap(err, "client.UploadStreamRequest")
 }
@@ -248,6 +295,15 @@ func (be *Backend) openReader(ctx context.Context, h restic.Handle, length int,
 		return nil, err
 	}
 
+	// cfg.StorageTier only governs the tier newly-written objects get; an
+	// object can end up Archive some other way (an OCI lifecycle policy, or
+	// cfg.StorageTier having changed since it was written), so the only
+	// reliable signal is the object's actual ArchivalState, which
+	// ensureRestored checks via HeadObject.
+	if err := be.ensureRestored(ctx, ociNamespace, objName); err != nil {
+		return nil, err
+	}
+
 	if bytesRange == "" {
 		request = objectstorage.GetObjectRequest{
 			NamespaceName: common.String(ociNamespace),
@@ -265,14 +321,209 @@ func (be *Backend) openReader(ctx context.Context, h restic.Handle, length int,
 		}
 	}
 
+	be.applySseToGet(&request)
+
 	resp, err := be.client.GetObject(ctx, request)
 	if err != nil {
 		return nil, err
 	}
-	
+
 	return io.NopCloser(resp.Content), nil
 }
 
+// ensureRestored checks whether the object is on Archive storage tier and,
+// if so, kicks off a restore. If cfg.ArchiveRestoreWait is false it returns
+// ErrObjectArchived immediately; otherwise it polls (with exponential
+// backoff, capped at one minute) until the object becomes readable or
+// be.cfg.ArchiveRestoreTimeout elapses.
+func (be *Backend) ensureRestored(ctx context.Context, ociNamespace, objName string) error {
+	headReq := objectstorage.HeadObjectRequest{
+		NamespaceName: common.String(ociNamespace),
+		BucketName:    common.String(be.cfg.BucketName),
+		ObjectName:    common.String(objName),
+	}
+	be.applySseToHead(&headReq)
+
+	head, err := be.client.HeadObject(ctx, headReq)
+	if err != nil {
+		return errors.Wrap(err, "HeadObject")
+	}
+
+	switch head.ArchivalState {
+	case objectstorage.ArchivalStateArchived:
+		debug.Log("%v is archived, requesting restore", objName)
+		hours := be.cfg.ArchiveRestoreHours
+		if hours <= 0 {
+			hours = 24
+		}
+		_, err := be.client.RestoreObjects(ctx, objectstorage.RestoreObjectsRequest{
+			NamespaceName: common.String(ociNamespace),
+			BucketName:    common.String(be.cfg.BucketName),
+			RestoreObjectsDetails: objectstorage.RestoreObjectsDetails{
+				ObjectName: common.String(objName),
+				Hours:      common.Int(hours),
+			},
+		})
+		if err != nil {
+			return errors.Wrap(err, "RestoreObjects")
+		}
+	case objectstorage.ArchivalStateRestoring, objectstorage.ArchivalStateAvailable, objectstorage.ArchivalStateRestored:
+		// an Available or Restored object is readable and returns just
+		// below; a Restoring one waits in the poll loop.
+	}
+
+	if head.ArchivalState == "" || head.ArchivalState == objectstorage.ArchivalStateAvailable || head.ArchivalState == objectstorage.ArchivalStateRestored {
+		return nil
+	}
+
+	if !be.cfg.ArchiveRestoreWait {
+		return ErrObjectArchived
+	}
+
+	timeout := be.cfg.ArchiveRestoreTimeout
+	if timeout <= 0 {
+		timeout = 12 * time.Hour
+	}
+	deadline := time.Now().Add(timeout)
+	backoff := 5 * time.Second
+	const maxBackoff = time.Minute
+
+	for {
+		if time.Now().After(deadline) {
+			return errors.Errorf("timed out after %v waiting for %v to be restored from archive", timeout, objName)
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(backoff):
+		}
+
+		head, err := be.client.HeadObject(ctx, headReq)
+		if err != nil {
+			return errors.Wrap(err, "HeadObject")
+		}
+		if head.ArchivalState == objectstorage.ArchivalStateAvailable || head.ArchivalState == objectstorage.ArchivalStateRestored || head.ArchivalState == "" {
+			return nil
+		}
+
+		debug.Log("%v archival state is %v, still waiting", objName, head.ArchivalState)
+		backoff *= 2
+		if backoff > maxBackoff {
+			backoff = maxBackoff
+		}
+	}
+}
+
+// RestoreAll issues a RestoreObjects request for every pack, key, lock,
+// snapshot and index file under this backend's prefix, to warm a cold
+// repository before running restic restore/check against it. It does not
+// check an object's storage tier first, and the config file is not included.
+// No CLI flag that calls it is present in this source tree.
+func (be *Backend) RestoreAll(ctx context.Context) error {
+	ociNamespace, err := getOCINamespace(ctx, be.client)
+	if err != nil {
+		return err
+	}
+
+	hours := be.cfg.ArchiveRestoreHours
+	if hours <= 0 {
+		hours = 24
+	}
+
+	fileTypes := []restic.FileType{
+		restic.PackFile, restic.KeyFile, restic.LockFile,
+		restic.SnapshotFile, restic.IndexFile,
+	}
+	for _, t := range fileTypes {
+		err := be.List(ctx, t, func(fi restic.FileInfo) error {
+			objName := be.Filename(restic.Handle{Type: t, Name: fi.Name})
+			_, err := be.client.RestoreObjects(ctx, objectstorage.RestoreObjectsRequest{
+				NamespaceName: common.String(ociNamespace),
+				BucketName:    common.String(be.cfg.BucketName),
+				RestoreObjectsDetails: objectstorage.RestoreObjectsDetails{
+					ObjectName: common.String(objName),
+					Hours:      common.Int(hours),
+				},
+			})
+			return errors.Wrap(err, "RestoreObjects")
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// MintPAR creates an OCI Pre-Authenticated Request scoped to this backend's
+// prefix and returns its full URL, suitable for the "oci:par:<url>" config
+// form consumed by Open. It backs a `restic oci par create` subcommand that
+// isn't present in this source tree. readWrite selects an OCI read-write PAR
+// instead of a read-only one; the backend Open builds from either form is
+// read-only (see ErrParReadOnly).
+func (be *Backend) MintPAR(ctx context.Context, readWrite bool, ttl time.Duration) (string, error) {
+	ociNamespace, err := getOCINamespace(ctx, be.client)
+	if err != nil {
+		return "", err
+	}
+
+	accessType := objectstorage.CreatePreauthenticatedRequestDetailsAccessTypeAnyObjectRead
+	if readWrite {
+		accessType = objectstorage.CreatePreauthenticatedRequestDetailsAccessTypeAnyObjectReadWrite
+	}
+
+	resp, err := be.client.CreatePreauthenticatedRequest(ctx, objectstorage.CreatePreauthenticatedRequestRequest{
+		NamespaceName: common.String(ociNamespace),
+		BucketName:    common.String(be.cfg.BucketName),
+		CreatePreauthenticatedRequestDetails: objectstorage.CreatePreauthenticatedRequestDetails{
+			Name:       common.String(fmt.Sprintf("restic-%s-%d", be.cfg.Prefix, time.Now().Unix())),
+			AccessType: accessType,
+			// OCI defaults BucketListingAction to deny; parBackend.List needs
+			// the ".../o" listing endpoint, so it must be requested explicitly.
+			BucketListingAction: objectstorage.CreatePreauthenticatedRequestDetailsBucketListingActionListObjects,
+			ObjectNamePrefix:    common.String(objectNamePrefix(be.cfg.Prefix)),
+			TimeExpires:         &common.SDKTime{Time: time.Now().Add(ttl)},
+		},
+	})
+	if err != nil {
+		return "", errors.Wrap(err, "CreatePreauthenticatedRequest")
+	}
+
+	host := be.client.Host
+	if !strings.Contains(host, "://") {
+		host = "https://" + host
+	}
+	return strings.TrimSuffix(host, "/") + SafeDeref[string](resp.AccessUri), nil
+}
+
+// HasPendingMultipartUpload reports whether the bucket has an in-progress,
+// unaborted multipart upload for h. It exists so tests can assert that a
+// failed or canceled Save doesn't leave a multipart upload dangling on the
+// bucket (which OCI keeps, and bills storage for, until it's aborted or
+// completed) - restic itself has no need to query this.
+func (be *Backend) HasPendingMultipartUpload(ctx context.Context, h restic.Handle) (bool, error) {
+	ociNamespace, err := getOCINamespace(ctx, be.client)
+	if err != nil {
+		return false, err
+	}
+
+	objName := be.Filename(h)
+	resp, err := be.client.ListMultipartUploads(ctx, objectstorage.ListMultipartUploadsRequest{
+		NamespaceName: common.String(ociNamespace),
+		BucketName:    common.String(be.cfg.BucketName),
+	})
+	if err != nil {
+		return false, errors.Wrap(err, "ListMultipartUploads")
+	}
+
+	for _, u := range resp.Items {
+		if SafeDeref[string](u.Object) == objName {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
 // Stat returns information about a blob.
 func (be *Backend) Stat(ctx context.Context, h restic.Handle) (bi restic.FileInfo, err error) {
 	objName := be.Filename(h)
@@ -281,17 +532,23 @@ func (be *Backend) Stat(ctx context.Context, h restic.Handle) (bi restic.FileInf
 		return restic.FileInfo{}, err
 	}
 
-	getObjectDetails, err := be.client.HeadObject(ctx, objectstorage.HeadObjectRequest{
+	headReq := objectstorage.HeadObjectRequest{
 		NamespaceName: common.String(ociNamespace),
 		BucketName:    common.String(be.cfg.BucketName),
 		ObjectName:    common.String(objName),
-	})
+	}
+	be.applySseToHead(&headReq)
+
+	getObjectDetails, err := be.client.HeadObject(ctx, headReq)
 	if err != nil {
 		return restic.FileInfo{}, errors.Wrap(err, "Stat")
 	}
 	if getObjectDetails.RawResponse.StatusCode == 404 {
 		return restic.FileInfo{}, errors.Wrap(err, "File not found")
 	}
+	if etag := SafeDeref[string](getObjectDetails.ETag); etag != "" {
+		be.etags.Store(h, etag)
+	}
 
 	objNameSlice := strings.Split(objName, "/")
 	return restic.FileInfo{Size: SafeDeref[int64](getObjectDetails.ContentLength), Name: objNameSlice[len(objNameSlice)-1]}, nil
@@ -306,7 +563,12 @@ func (be *Backend) Remove(ctx context.Context, h restic.Handle) error {
 		return err
 	}
 
-	err = deleteObject(ctx, be.client, ociNamespace, be.cfg.BucketName, objName)
+	var ifMatch *string
+	if etag, ok := be.etags.Load(h); ok {
+		ifMatch = common.String(etag.(string))
+	}
+	err = deleteObject(ctx, be.client, ociNamespace, be.cfg.BucketName, objName, ifMatch)
+	be.etags.Delete(h)
 
 	if be.IsNotExist(err) {
 		err = nil
@@ -315,6 +577,10 @@ func (be *Backend) Remove(ctx context.Context, h restic.Handle) error {
 	return errors.Wrap(err, "client.RemoveObject")
 }
 
+// listObjectFields requests the object attributes needed to build a
+// restic.FileInfo directly from ListObjects, avoiding a HeadObject per entry.
+const listObjectFields = "name,size,etag,md5,timeCreated"
+
 // List runs fn for each file in the backend which has the type t. When an
 // error occurs (or fn returns an error), List stops and returns it.
 func (be *Backend) List(ctx context.Context, t restic.FileType, fn func(restic.FileInfo) error) error {
@@ -332,47 +598,49 @@ func (be *Backend) List(ctx context.Context, t restic.FileType, fn func(restic.F
 		return err
 	}
 
-	listresp, err := be.client.ListObjects(ctx, objectstorage.ListObjectsRequest{
-		NamespaceName: common.String(ociNamespace),
-		BucketName:    common.String(be.cfg.BucketName),
-		Prefix:        common.String(prefix),
-	})
-	if err != nil {
-		return err
-	}
-
-	for _, obj := range listresp.Objects {
-		name := strings.TrimPrefix(SafeDeref[string](obj.Name), prefix)
-		if name == "" {
-			continue
-		}
-
-		getObjectDetails, err := be.client.HeadObject(ctx, objectstorage.HeadObjectRequest{
+	start := common.String("")
+	for {
+		listresp, err := be.client.ListObjects(ctx, objectstorage.ListObjectsRequest{
 			NamespaceName: common.String(ociNamespace),
 			BucketName:    common.String(be.cfg.BucketName),
-			ObjectName:    common.String(SafeDeref[string](obj.Name)),
+			Prefix:        common.String(prefix),
+			Fields:        common.String(listObjectFields),
+			Start:         start,
 		})
 		if err != nil {
 			return err
 		}
 
-		fi := restic.FileInfo{
-			Name: path.Base(name),
-			Size: SafeDeref[int64](getObjectDetails.ContentLength),
-		}
-
-		if ctx.Err() != nil {
-			return ctx.Err()
+		for _, obj := range listresp.Objects {
+			name := strings.TrimPrefix(SafeDeref[string](obj.Name), prefix)
+			if name == "" {
+				continue
+			}
+
+			fi := restic.FileInfo{
+				Name: path.Base(name),
+				Size: SafeDeref[int64](obj.Size),
+			}
+			be.cacheListETag(restic.Handle{Type: t, Name: fi.Name}, obj)
+
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+
+			err = fn(fi)
+			if err != nil {
+				return err
+			}
+
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
 		}
 
-		err = fn(fi)
-		if err != nil {
-			return err
-		}
-
-		if ctx.Err() != nil {
-			return ctx.Err()
+		if listresp.NextStartWith == nil || SafeDeref[string](listresp.NextStartWith) == "" {
+			break
 		}
+		start = listresp.NextStartWith
 	}
 
 	return ctx.Err()
@@ -404,7 +672,7 @@ func (be *Backend) Rename(ctx context.Context, h restic.Handle, l layout.Layout)
 		return err
 	}
 
-	_, err = be.client.CopyObject(ctx, objectstorage.CopyObjectRequest{
+	copyReq := objectstorage.CopyObjectRequest{
 		NamespaceName: common.String(ociNamespace),
 		BucketName:    common.String(be.cfg.BucketName),
 		CopyObjectDetails: objectstorage.CopyObjectDetails{
@@ -414,7 +682,10 @@ func (be *Backend) Rename(ctx context.Context, h restic.Handle, l layout.Layout)
 			DestinationRegion:     common.String(be.cfg.Region),
 			DestinationNamespace:  common.String(ociNamespace),
 		},
-	})
+	}
+	be.applySseToCopy(&copyReq)
+
+	_, err = be.client.CopyObject(ctx, copyReq)
 	if err != nil && be.IsNotExist(err) {
 		debug.Log("copy failed: %v, seems to already have been renamed", err)
 		return nil
@@ -424,40 +695,60 @@ func (be *Backend) Rename(ctx context.Context, h restic.Handle, l layout.Layout)
 		debug.Log("copy failed: %v", err)
 		return err
 	}
-	return deleteObject(ctx, be.client, ociNamespace, be.cfg.BucketName, oldname)
+	return deleteObject(ctx, be.client, ociNamespace, be.cfg.BucketName, oldname, nil)
 }
 
-// ensureBucketExists checks for existence of bucket inside the compartment.
-func ensureBucketExists(ctx context.Context, client objectstorage.ObjectStorageClient, namespace, name string, compartmentOCID string) error {
+// ensureBucketExists checks for existence of bucket inside the compartment,
+// creating it if it is missing. Any error other than "bucket not found" is
+// propagated rather than swallowed.
+func ensureBucketExists(ctx context.Context, client objectstorage.ObjectStorageClient, namespace, name string, cfg Config) error {
 	req := objectstorage.GetBucketRequest{
 		NamespaceName: &namespace,
 		BucketName:    &name,
 	}
-	// verify if bucket exists
-	response, err := client.GetBucket(context.Background(), req)
-	if err != nil {
-		if 404 == response.RawResponse.StatusCode {
-			return createBucket(ctx, client, namespace, name, compartmentOCID)
-		}
+
+	_, err := client.GetBucket(ctx, req)
+	if err == nil {
+		return nil
 	}
-	return nil
+
+	var svcErr common.ServiceError
+	if !errors.As(err, &svcErr) || svcErr.GetHTTPStatusCode() != 404 {
+		return errors.Wrap(err, "GetBucket")
+	}
+
+	return createBucket(ctx, client, namespace, name, cfg)
 }
 
 // createBucket creates a bucket in a compartment.
 // bucketname needs to be unique within compartment. there is no concept of "child" buckets.
-func createBucket(ctx context.Context, client objectstorage.ObjectStorageClient, namespace string, name string, compartmentOCID string) error {
+func createBucket(ctx context.Context, client objectstorage.ObjectStorageClient, namespace string, name string, cfg Config) error {
+	details := objectstorage.CreateBucketDetails{
+		CompartmentId:    common.String(cfg.CompartmentOCID),
+		Name:             common.String(name),
+		Metadata:         make(map[string]string),
+		PublicAccessType: objectstorage.CreateBucketDetailsPublicAccessTypeNopublicaccess,
+	}
+
+	if cfg.BucketPublicAccessType != "" {
+		details.PublicAccessType = objectstorage.CreateBucketDetailsPublicAccessTypeEnum(cfg.BucketPublicAccessType)
+	}
+	if cfg.BucketVersioning != "" {
+		details.Versioning = objectstorage.CreateBucketDetailsVersioningEnum(cfg.BucketVersioning)
+	}
+	if cfg.BucketAutoTiering != "" {
+		details.AutoTiering = objectstorage.CreateBucketDetailsAutoTieringEnum(cfg.BucketAutoTiering)
+	}
+	if cfg.BucketKmsKeyID != "" {
+		details.KmsKeyId = common.String(cfg.BucketKmsKeyID)
+	}
+
 	request := objectstorage.CreateBucketRequest{
-		NamespaceName: &namespace,
+		NamespaceName:       common.String(namespace),
+		CreateBucketDetails: details,
 	}
-	request.CompartmentId = common.String(compartmentOCID)
-	request.Name = common.String(name)
-	request.Metadata = make(map[string]string)
-	request.PublicAccessType = objectstorage.CreateBucketDetailsPublicAccessTypeNopublicaccess
 	_, err := client.CreateBucket(ctx, request)
-	if err != nil {
-		return err
-	}
-	return nil
+	return errors.Wrap(err, "CreateBucket")
 }
 
 // getOCINamespace fetches the tenancy namespace to be used by the OCI object store client
@@ -470,12 +761,14 @@ func getOCINamespace(ctx context.Context, client objectstorage.ObjectStorageClie
 	return SafeDeref[string](r.Value), nil
 }
 
-// deleteObject deletes an objet from OCI object store
-func deleteObject(ctx context.Context, c objectstorage.ObjectStorageClient, namespace, bucketname, objectname string) error {
+// deleteObject deletes an objet from OCI object store. If ifMatch is
+// non-nil, the delete is conditional on the object still having that ETag.
+func deleteObject(ctx context.Context, c objectstorage.ObjectStorageClient, namespace, bucketname, objectname string, ifMatch *string) error {
 	request := objectstorage.DeleteObjectRequest{
 		NamespaceName: common.String(namespace),
 		BucketName:    common.String(bucketname),
 		ObjectName:    common.String(objectname),
+		IfMatch:       ifMatch,
 	}
 	_, err := c.DeleteObject(ctx, request)
 	if err != nil {
@@ -484,6 +777,57 @@ func deleteObject(ctx context.Context, c objectstorage.ObjectStorageClient, name
 	return nil
 }
 
+// applySseToUpload sets the customer-provided and KMS encryption options on
+// an upload (Save) request, if configured.
+func (be *Backend) applySseToUpload(req *transfer.UploadRequest) {
+	if be.cfg.SseCustomerAlgorithm != "" {
+		req.SseCustomerAlgorithm = common.String(be.cfg.SseCustomerAlgorithm)
+		req.SseCustomerKey = common.String(be.cfg.SseCustomerKey.Unwrap())
+		req.SseCustomerKeySha256 = common.String(be.cfg.SseCustomerKeySha256)
+	}
+	if be.cfg.SseKmsKeyID != "" {
+		req.OpcSseKmsKeyId = common.String(be.cfg.SseKmsKeyID)
+	}
+}
+
+// applySseToGet sets the customer-provided encryption options on a
+// GetObject (Load) request, if configured.
+func (be *Backend) applySseToGet(req *objectstorage.GetObjectRequest) {
+	if be.cfg.SseCustomerAlgorithm != "" {
+		req.SseCustomerAlgorithm = common.String(be.cfg.SseCustomerAlgorithm)
+		req.SseCustomerKey = common.String(be.cfg.SseCustomerKey.Unwrap())
+		req.SseCustomerKeySha256 = common.String(be.cfg.SseCustomerKeySha256)
+	}
+}
+
+// applySseToHead sets the customer-provided encryption options on a
+// HeadObject (Stat) request, if configured.
+func (be *Backend) applySseToHead(req *objectstorage.HeadObjectRequest) {
+	if be.cfg.SseCustomerAlgorithm != "" {
+		req.SseCustomerAlgorithm = common.String(be.cfg.SseCustomerAlgorithm)
+		req.SseCustomerKey = common.String(be.cfg.SseCustomerKey.Unwrap())
+		req.SseCustomerKeySha256 = common.String(be.cfg.SseCustomerKeySha256)
+	}
+}
+
+// applySseToCopy sets the customer-provided and KMS encryption options on a
+// CopyObject (Rename) request, if configured. The source key is the same as
+// the destination key since this backend always re-encrypts with its own
+// configured key material.
+func (be *Backend) applySseToCopy(req *objectstorage.CopyObjectRequest) {
+	if be.cfg.SseCustomerAlgorithm != "" {
+		req.SseCustomerAlgorithm = common.String(be.cfg.SseCustomerAlgorithm)
+		req.SseCustomerKey = common.String(be.cfg.SseCustomerKey.Unwrap())
+		req.SseCustomerKeySha256 = common.String(be.cfg.SseCustomerKeySha256)
+		req.SourceSseCustomerAlgorithm = common.String(be.cfg.SseCustomerAlgorithm)
+		req.SourceSseCustomerKey = common.String(be.cfg.SseCustomerKey.Unwrap())
+		req.SourceSseCustomerKeySha256 = common.String(be.cfg.SseCustomerKeySha256)
+	}
+	if be.cfg.SseKmsKeyID != "" {
+		req.OpcSseKmsKeyId = common.String(be.cfg.SseKmsKeyID)
+	}
+}
+
 // SafeDeref returns the de-refernced value of a pointer variable and takes into account when pointer is nil.
 func SafeDeref[T any](p *T) T {
 	if p == nil {
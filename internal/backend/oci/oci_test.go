@@ -3,6 +3,7 @@ package oci_test
 import (
 	"bytes"
 	"context"
+	"errors"
 	"fmt"
 	"github.com/restic/restic/internal/backend"
 	"github.com/restic/restic/internal/backend/oci"
@@ -29,6 +30,14 @@ func newOCITestSuite() *test.Suite[oci.Config] {
 
 			cfg.ApplyEnvironment("RESTIC_TEST_")
 			cfg.Prefix = fmt.Sprintf("test-%d", time.Now().UnixNano())
+
+			// RESTIC_TEST_OCI_AUTH picks an OraclePrincipalType for this
+			// suite run (e.g. "instance_principal"), overriding whatever
+			// ApplyEnvironment derived from OCI_AUTH_TYPE.
+			if mode := os.Getenv("RESTIC_TEST_OCI_AUTH"); mode != "" {
+				cfg.OCIAuthType = authTypeForTestMode(mode)
+			}
+
 			return cfg, nil
 		},
 
@@ -56,15 +65,196 @@ func TestUploadLargeFile(t *testing.T) {
 		}
 	}
 
-	ctx, cancel := context.WithCancel(context.TODO())
-	defer cancel()
+	// each part size is exercised against the same upload/download matrix,
+	// so a regression in the part-size/concurrency wiring shows up
+	// regardless of how the multipart upload happens to be chunked.
+	partSizes := []int64{
+		5 * 1024 * 1024,
+		64 * 1024 * 1024,
+		128 * 1024 * 1024,
+	}
+
+	for _, partSize := range partSizes {
+		partSize := partSize
+		t.Run(fmt.Sprintf("part-size-%d", partSize), func(t *testing.T) {
+			ctx, cancel := context.WithCancel(context.TODO())
+			defer cancel()
+
+			cfg, err := oci.ParseConfig(os.Getenv("RESTIC_TEST_OCI_REPOSITORY"))
+			if err != nil {
+				t.Fatal(err)
+			}
+			cfg.ApplyEnvironment("RESTIC_TEST_")
+			cfg.Prefix = fmt.Sprintf("test-upload-large-%d", time.Now().UnixNano())
+			cfg.UploadPartSize = partSize
+
+			tr, err := backend.Transport(backend.TransportOptions{})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			be, err := oci.Create(ctx, *cfg, tr)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			defer func() {
+				err := be.Delete(ctx)
+				if err != nil {
+					t.Fatal(err)
+				}
+			}()
+
+			data := rtest.Random(23, 300*1024*1024)
+			id := restic.Hash(data)
+			h := restic.Handle{Name: id.String(), Type: restic.PackFile}
+
+			t.Logf("hash of %d bytes: %v", len(data), id)
+
+			err = be.Save(ctx, h, restic.NewByteReader(data, be.Hasher()))
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer func() {
+				err := be.Remove(ctx, h)
+				if err != nil {
+					t.Fatal(err)
+				}
+			}()
+
+			var tests = []struct {
+				offset, length int
+			}{
+				{0, len(data)},
+				{23, 1024},
+				{23 + 100*1024, 500},
+				{888 + 200*1024, 89999},
+				{888 + 100*1024*1024, 120 * 1024 * 1024},
+			}
+
+			for _, test := range tests {
+				t.Run("", func(t *testing.T) {
+					want := data[test.offset : test.offset+test.length]
+
+					buf := make([]byte, test.length)
+					err = be.Load(ctx, h, test.length, int64(test.offset), func(rd io.Reader) error {
+						_, err = io.ReadFull(rd, buf)
+						return err
+					})
+					if err != nil {
+						t.Fatal(err)
+					}
+
+					if !bytes.Equal(buf, want) {
+						t.Fatalf("wrong bytes returned")
+					}
+				})
+			}
+		})
+	}
+
+	t.Run("canceled-context-no-orphaned-upload", func(t *testing.T) {
+		cfg, err := oci.ParseConfig(os.Getenv("RESTIC_TEST_OCI_REPOSITORY"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		cfg.ApplyEnvironment("RESTIC_TEST_")
+		cfg.Prefix = fmt.Sprintf("test-upload-large-cancel-%d", time.Now().UnixNano())
+
+		tr, err := backend.Transport(backend.TransportOptions{})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		ctx, cancel := context.WithCancel(context.TODO())
+		be, err := oci.Create(ctx, *cfg, tr)
+		if err != nil {
+			cancel()
+			t.Fatal(err)
+		}
+		defer func() {
+			err := be.Delete(context.TODO())
+			if err != nil {
+				t.Fatal(err)
+			}
+		}()
+
+		ociBe, ok := be.(*oci.Backend)
+		if !ok {
+			t.Fatalf("oci.Create returned %T, not *oci.Backend", be)
+		}
+
+		data := rtest.Random(24, 300*1024*1024)
+		id := restic.Hash(data)
+		h := restic.Handle{Name: id.String(), Type: restic.PackFile}
+
+		// cancel partway through the upload, once the first part has had
+		// time to go out, so the in-progress multipart upload is aborted
+		// rather than left dangling on the bucket.
+		go func() {
+			time.Sleep(time.Second)
+			cancel()
+		}()
+		err = be.Save(ctx, h, restic.NewByteReader(data, be.Hasher()))
+		if err == nil {
+			t.Fatal("expected Save to fail after context cancellation")
+		}
+
+		err = be.List(context.TODO(), restic.PackFile, func(fi restic.FileInfo) error {
+			if fi.Name == h.Name {
+				t.Fatalf("found orphaned object %v after canceled upload", fi.Name)
+			}
+			return nil
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		pending, err := ociBe.HasPendingMultipartUpload(context.TODO(), h)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if pending {
+			t.Fatalf("found dangling multipart upload for %v after canceled upload", h.Name)
+		}
+	})
+}
+
+// TestArchiveTierRestore verifies Stat/Load semantics against an
+// Archive-tier object whose restore is still in progress: Stat must keep
+// working (HeadObject succeeds on archived objects), while Load with
+// ArchiveRestoreWait disabled must kick off the restore and fail fast with
+// ErrObjectArchived instead of blocking - an OCI archive restore can take
+// hours, far longer than this test should wait.
+func TestArchiveTierRestore(t *testing.T) {
+	vars := []string{
+		"RESTIC_TEST_OCI_REGION",
+		"RESTIC_TEST_OCI_USER",
+		"RESTIC_TEST_OCI_FINGERPRINT",
+		"RESTIC_TEST_OCI_KEY_FILE",
+		"RESTIC_TEST_OCI_TENANCY",
+		"RESTIC_TEST_OCI_COMPARTMENT_OCID",
+		"RESTIC_TEST_OCI_REPOSITORY",
+		"RESTIC_OCI_TEST_ARCHIVE_RESTORE",
+	}
+
+	for _, v := range vars {
+		if os.Getenv(v) == "" {
+			t.Skipf("environment variable %v not set", v)
+			return
+		}
+	}
+
+	ctx := context.TODO()
 
 	cfg, err := oci.ParseConfig(os.Getenv("RESTIC_TEST_OCI_REPOSITORY"))
 	if err != nil {
 		t.Fatal(err)
 	}
 	cfg.ApplyEnvironment("RESTIC_TEST_")
-	cfg.Prefix = fmt.Sprintf("test-upload-large-%d", time.Now().UnixNano())
+	cfg.Prefix = fmt.Sprintf("test-archive-restore-%d", time.Now().UnixNano())
+	cfg.StorageTier = oci.StorageTierArchive
+	cfg.ArchiveRestoreWait = false
 
 	tr, err := backend.Transport(backend.TransportOptions{})
 	if err != nil {
@@ -75,7 +265,6 @@ func TestUploadLargeFile(t *testing.T) {
 	if err != nil {
 		t.Fatal(err)
 	}
-
 	defer func() {
 		err := be.Delete(ctx)
 		if err != nil {
@@ -83,12 +272,10 @@ func TestUploadLargeFile(t *testing.T) {
 		}
 	}()
 
-	data := rtest.Random(23, 300*1024*1024)
+	data := rtest.Random(42, 1024)
 	id := restic.Hash(data)
 	h := restic.Handle{Name: id.String(), Type: restic.PackFile}
 
-	t.Logf("hash of %d bytes: %v", len(data), id)
-
 	err = be.Save(ctx, h, restic.NewByteReader(data, be.Hasher()))
 	if err != nil {
 		t.Fatal(err)
@@ -100,33 +287,132 @@ func TestUploadLargeFile(t *testing.T) {
 		}
 	}()
 
-	var tests = []struct {
-		offset, length int
-	}{
-		{0, len(data)},
-		{23, 1024},
-		{23 + 100*1024, 500},
-		{888 + 200*1024, 89999},
-		{888 + 100*1024*1024, 120 * 1024 * 1024},
-	}
-
-	for _, test := range tests {
-		t.Run("", func(t *testing.T) {
-			want := data[test.offset : test.offset+test.length]
-
-			buf := make([]byte, test.length)
-			err = be.Load(ctx, h, test.length, int64(test.offset), func(rd io.Reader) error {
-				_, err = io.ReadFull(rd, buf)
-				return err
-			})
-			if err != nil {
-				t.Fatal(err)
-			}
+	if _, err := be.Stat(ctx, h); err != nil {
+		t.Fatalf("Stat on archived object failed: %v", err)
+	}
 
-			if !bytes.Equal(buf, want) {
-				t.Fatalf("wrong bytes returned")
-			}
-		})
+	err = be.Load(ctx, h, 0, 0, func(rd io.Reader) error {
+		_, err := io.ReadAll(rd)
+		return err
+	})
+	if !errors.Is(err, oci.ErrObjectArchived) {
+		t.Fatalf("expected ErrObjectArchived, got %v", err)
+	}
+}
+
+// TestBackendOCIPar verifies that a repository written through the normal
+// API-key backend can be read back through a backend opened from a freshly
+// minted Pre-Authenticated Request URL, exercising Stat/List/Load against
+// the "oci:par:<url>" config form instead of API-key auth.
+func TestBackendOCIPar(t *testing.T) {
+	vars := []string{
+		"RESTIC_TEST_OCI_REGION",
+		"RESTIC_TEST_OCI_USER",
+		"RESTIC_TEST_OCI_FINGERPRINT",
+		"RESTIC_TEST_OCI_KEY_FILE",
+		"RESTIC_TEST_OCI_TENANCY",
+		"RESTIC_TEST_OCI_COMPARTMENT_OCID",
+		"RESTIC_TEST_OCI_REPOSITORY",
+		"RESTIC_OCI_TEST_PAR",
+	}
+	for _, v := range vars {
+		if os.Getenv(v) == "" {
+			t.Skipf("environment variable %v not set", v)
+			return
+		}
+	}
+
+	ctx := context.TODO()
+
+	cfg, err := oci.ParseConfig(os.Getenv("RESTIC_TEST_OCI_REPOSITORY"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	cfg.ApplyEnvironment("RESTIC_TEST_")
+	cfg.Prefix = fmt.Sprintf("test-par-%d", time.Now().UnixNano())
+
+	tr, err := backend.Transport(backend.TransportOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	rbe, err := oci.Create(ctx, *cfg, tr)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() {
+		err := rbe.Delete(ctx)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}()
+
+	be, ok := rbe.(*oci.Backend)
+	if !ok {
+		t.Fatalf("oci.Create returned %T, not *oci.Backend", rbe)
+	}
+
+	data := rtest.Random(7, 4096)
+	id := restic.Hash(data)
+	h := restic.Handle{Name: id.String(), Type: restic.PackFile}
+
+	err = be.Save(ctx, h, restic.NewByteReader(data, be.Hasher()))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	parURL, err := be.MintPAR(ctx, true, time.Hour)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	parCfg, err := oci.ParseConfig("oci:par:" + parURL)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	parBe, err := oci.Open(ctx, *parCfg, tr)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer parBe.Close()
+
+	fi, err := parBe.Stat(ctx, h)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if fi.Size != int64(len(data)) {
+		t.Fatalf("wrong size reported by par backend: got %d, want %d", fi.Size, len(data))
+	}
+
+	var found bool
+	err = parBe.List(ctx, restic.PackFile, func(fi restic.FileInfo) error {
+		if fi.Name == h.Name {
+			found = true
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !found {
+		t.Fatalf("par backend List did not report %v", h.Name)
+	}
+
+	var buf bytes.Buffer
+	err = parBe.Load(ctx, h, 0, 0, func(rd io.Reader) error {
+		_, err := io.Copy(&buf, rd)
+		return err
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(buf.Bytes(), data) {
+		t.Fatalf("wrong data read back through par backend")
+	}
+
+	if err := be.Remove(ctx, h); err != nil {
+		t.Fatal(err)
 	}
 }
 
@@ -158,6 +444,45 @@ func TestBackendOCI(t *testing.T) {
 	newOCITestSuite().RunTests(t)
 }
 
+// authTypeForTestMode maps the short mode names accepted by
+// RESTIC_TEST_OCI_AUTH to the corresponding oci.OraclePrincipalType.
+func authTypeForTestMode(mode string) oci.OraclePrincipalType {
+	switch mode {
+	case "instance_principal":
+		return oci.InstancePrincipal
+	case "resource_principal":
+		return oci.ResourcePrincipal
+	case "workload":
+		return oci.WorkloadPrincipal
+	default:
+		return oci.UserPrincipal
+	}
+}
+
+// TestBackendOCIInstancePrincipal runs the backend test suite authenticating
+// via an OCI instance principal instead of API-key credentials. It only runs
+// on an actual OCI compute instance with a dynamic group allowing object
+// storage access, so it is opt-in via RESTIC_TEST_OCI_AUTH.
+func TestBackendOCIInstancePrincipal(t *testing.T) {
+	if os.Getenv("RESTIC_TEST_OCI_AUTH") != "instance_principal" {
+		t.Skip("RESTIC_TEST_OCI_AUTH=instance_principal not set")
+	}
+
+	vars := []string{
+		"RESTIC_TEST_OCI_COMPARTMENT_OCID",
+		"RESTIC_TEST_OCI_REPOSITORY",
+	}
+	for _, v := range vars {
+		if os.Getenv(v) == "" {
+			t.Skipf("environment variable %v not set", v)
+			return
+		}
+	}
+
+	t.Logf("run tests with instance principal auth")
+	newOCITestSuite().RunTests(t)
+}
+
 func BenchmarkBackendOCI(t *testing.B) {
 	vars := []string{
 		"RESTIC_TEST_OCI_REGION",
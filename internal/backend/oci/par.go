@@ -0,0 +1,289 @@
+package oci
+
+import (
+	"context"
+	"crypto/md5"
+	"encoding/json"
+	"fmt"
+	"hash"
+	"io"
+	"net/http"
+	"net/url"
+	"path"
+	"strconv"
+	"strings"
+
+	"github.com/restic/restic/internal/backend"
+	"github.com/restic/restic/internal/backend/layout"
+	"github.com/restic/restic/internal/errors"
+	"github.com/restic/restic/internal/restic"
+)
+
+// ErrParReadOnly is returned by the write operations of a backend opened
+// through an "oci:par:<url>" Pre-Authenticated Request URL. That backend never
+// writes, even through a read-write PAR minted by Backend.MintPAR, since
+// restic's conditional writes and multipart uploads aren't meaningfully
+// expressible against a single PAR.
+var ErrParReadOnly = errors.New("oci: par backend is read-only")
+
+// parBackend is a restic.Backend backed entirely by an OCI bucket-listing
+// Pre-Authenticated Request URL: it authenticates purely via the URL, with
+// none of the tenancy/user/key setup the API-key backend needs. It supports
+// List, Stat and Load; Save and Remove always fail with ErrParReadOnly.
+type parBackend struct {
+	client  *http.Client
+	baseURL string
+	cfg     Config
+	layout.Layout
+}
+
+// openPAR builds a parBackend from cfg.ParURL. It is selected by Open when
+// cfg.OCIAuthType is ParPrincipal.
+func openPAR(cfg Config, rt http.RoundTripper) (restic.Backend, error) {
+	if cfg.ParURL == "" {
+		return nil, errors.Fatalf("unable to open OCI par backend: par URL is empty")
+	}
+
+	return &parBackend{
+		client:  &http.Client{Transport: newRetryTransport(rt, cfg.RetryMaxElapsed, cfg.RetryMaxAttempts)},
+		baseURL: strings.TrimSuffix(cfg.ParURL, "/"),
+		cfg:     cfg,
+		Layout: &layout.DefaultLayout{
+			Path: cfg.Prefix,
+			Join: path.Join,
+		},
+	}, nil
+}
+
+// make sure that *parBackend implements restic.Backend
+var _ restic.Backend = &parBackend{}
+
+// parError records the status code of a failed request against the PAR URL.
+type parError struct {
+	status int
+	url    string
+}
+
+func (e *parError) Error() string {
+	return fmt.Sprintf("par request to %v failed with status %d", e.url, e.status)
+}
+
+// IsNotExist returns true if the error is caused by a not existing file.
+func (be *parBackend) IsNotExist(err error) bool {
+	var e *parError
+	return errors.As(err, &e) && e.status == http.StatusNotFound
+}
+
+// Join combines path components with slashes.
+func (be *parBackend) Join(p ...string) string {
+	return path.Join(p...)
+}
+
+func (be *parBackend) Connections() uint {
+	return be.cfg.Connections
+}
+
+// Location returns this backend's location (the PAR URL).
+func (be *parBackend) Location() string {
+	return be.baseURL
+}
+
+// Hasher may return a hash function for calculating a content hash for the backend
+func (be *parBackend) Hasher() hash.Hash {
+	return md5.New()
+}
+
+// HasAtomicReplace returns whether Save() can atomically replace files.
+func (be *parBackend) HasAtomicReplace() bool {
+	return false
+}
+
+// Path returns the path in the bucket that is used for this backend.
+func (be *parBackend) Path() string {
+	return be.cfg.Prefix
+}
+
+// Close does nothing.
+func (be *parBackend) Close() error { return nil }
+
+// Delete removes all restic keys. A PAR grants access to objects, not to the
+// bucket-management operations bulk-delete would need, so it always fails.
+func (be *parBackend) Delete(_ context.Context) error {
+	return errors.New("oci: par backend does not support Delete")
+}
+
+// Save is unsupported: see ErrParReadOnly.
+func (be *parBackend) Save(_ context.Context, _ restic.Handle, _ restic.RewindReader) error {
+	return ErrParReadOnly
+}
+
+// Remove is unsupported: see ErrParReadOnly.
+func (be *parBackend) Remove(_ context.Context, _ restic.Handle) error {
+	return ErrParReadOnly
+}
+
+// escapeObjectName percent-encodes each "/"-separated segment of name
+// individually and rejoins them with literal slashes. url.PathEscape on the
+// whole name would also escape the separators themselves (turning
+// "data/ab/abcdef" into "data%2Fab%2Fabcdef"), corrupting the object key.
+func escapeObjectName(name string) string {
+	segments := strings.Split(name, "/")
+	for i, s := range segments {
+		segments[i] = url.PathEscape(s)
+	}
+	return strings.Join(segments, "/")
+}
+
+// objectURL builds the URL for an individual object. be.baseURL is the PAR
+// URL, which for an object-read PAR ends in ".../o/", with that trailing slash
+// trimmed by openPAR - the slash is put back and the object name appended
+// directly, with no further path segment.
+func (be *parBackend) objectURL(h restic.Handle) string {
+	return be.baseURL + "/" + escapeObjectName(be.Filename(h))
+}
+
+// Stat returns information about a blob.
+func (be *parBackend) Stat(ctx context.Context, h restic.Handle) (restic.FileInfo, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodHead, be.objectURL(h), nil)
+	if err != nil {
+		return restic.FileInfo{}, err
+	}
+
+	resp, err := be.client.Do(req)
+	if err != nil {
+		return restic.FileInfo{}, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return restic.FileInfo{}, &parError{status: resp.StatusCode, url: req.URL.String()}
+	}
+
+	size, err := strconv.ParseInt(resp.Header.Get("Content-Length"), 10, 64)
+	if err != nil {
+		return restic.FileInfo{}, errors.Wrap(err, "ParseInt")
+	}
+
+	objName := be.Filename(h)
+	objNameSlice := strings.Split(objName, "/")
+	return restic.FileInfo{Size: size, Name: objNameSlice[len(objNameSlice)-1]}, nil
+}
+
+// Load runs fn with a reader that yields the contents of the file at h at the
+// given offset.
+func (be *parBackend) Load(ctx context.Context, h restic.Handle, length int, offset int64, fn func(rd io.Reader) error) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	return backend.DefaultLoad(ctx, h, length, offset, be.openReader, fn)
+}
+
+func (be *parBackend) openReader(ctx context.Context, h restic.Handle, length int, offset int64) (io.ReadCloser, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, be.objectURL(h), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var bytesRange string
+	if length > 0 {
+		bytesRange, err = getRange(offset, offset+int64(length)-1)
+	} else if offset > 0 {
+		bytesRange, err = getRange(offset, 0)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if bytesRange != "" {
+		req.Header.Set("Range", bytesRange)
+	}
+
+	resp, err := be.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusPartialContent {
+		defer resp.Body.Close()
+		return nil, &parError{status: resp.StatusCode, url: req.URL.String()}
+	}
+	return resp.Body, nil
+}
+
+// parListResponse mirrors the subset of OCI's ListObjects JSON response
+// returned when listing is performed through a bucket-listing-enabled PAR.
+type parListResponse struct {
+	Objects []struct {
+		Name string `json:"name"`
+		Size int64  `json:"size"`
+	} `json:"objects"`
+	NextStartWith string `json:"nextStartWith"`
+}
+
+// List runs fn for each file in the backend which has the type t. When an
+// error occurs (or fn returns an error), List stops and returns it.
+func (be *parBackend) List(ctx context.Context, t restic.FileType, fn func(restic.FileInfo) error) error {
+	prefix, _ := be.Basedir(t)
+	if !strings.HasSuffix(prefix, "/") {
+		prefix += "/"
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	start := ""
+	for {
+		q := url.Values{"prefix": {prefix}}
+		if start != "" {
+			q.Set("start", start)
+		}
+		// be.baseURL is the ".../o/" listing endpoint carried by the PAR's
+		// AccessUri, minus the trailing slash openPAR trimmed; the slash is
+		// put back and the query string appended to it.
+		reqURL := be.baseURL + "/?" + q.Encode()
+
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, reqURL, nil)
+		if err != nil {
+			return err
+		}
+
+		resp, err := be.client.Do(req)
+		if err != nil {
+			return err
+		}
+		if resp.StatusCode != http.StatusOK {
+			resp.Body.Close()
+			return &parError{status: resp.StatusCode, url: reqURL}
+		}
+
+		var listresp parListResponse
+		err = json.NewDecoder(resp.Body).Decode(&listresp)
+		resp.Body.Close()
+		if err != nil {
+			return errors.Wrap(err, "Decode")
+		}
+
+		for _, obj := range listresp.Objects {
+			name := strings.TrimPrefix(obj.Name, prefix)
+			if name == "" {
+				continue
+			}
+
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+
+			if err := fn(restic.FileInfo{Name: path.Base(name), Size: obj.Size}); err != nil {
+				return err
+			}
+
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+		}
+
+		if listresp.NextStartWith == "" {
+			break
+		}
+		start = listresp.NextStartWith
+	}
+
+	return ctx.Err()
+}
@@ -0,0 +1,268 @@
+package oci
+
+import (
+	"context"
+	"io"
+	"math/rand"
+	"net"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/oracle/oci-go-sdk/v65/common"
+	"github.com/oracle/oci-go-sdk/v65/objectstorage"
+	"github.com/oracle/oci-go-sdk/v65/objectstorage/transfer"
+	"github.com/restic/restic/internal/debug"
+	"github.com/restic/restic/internal/errors"
+	"github.com/restic/restic/internal/restic"
+)
+
+// retryableStatusCodes are the HTTP status codes that indicate a transient
+// failure worth retrying: throttling and server-side errors.
+var retryableStatusCodes = map[int]bool{
+	http.StatusTooManyRequests:     true,
+	http.StatusInternalServerError: true,
+	http.StatusBadGateway:          true,
+	http.StatusServiceUnavailable:  true,
+	http.StatusGatewayTimeout:      true,
+}
+
+// retryTransport wraps an http.RoundTripper and retries requests that fail
+// with OCI throttling or transient 5xx responses, honoring the Retry-After
+// header and backing off exponentially with jitter in between. Retries are
+// bounded by maxElapsed and maxAttempts.
+//
+// This only retries requests whose body can be replayed (GetBody is set, or
+// there is no body at all) - uploads built from a restic.RewindReader are
+// retried separately in Backend.Save, which rewinds the reader itself.
+type retryTransport struct {
+	next        http.RoundTripper
+	maxElapsed  time.Duration
+	maxAttempts int
+}
+
+func newRetryTransport(next http.RoundTripper, maxElapsed time.Duration, maxAttempts int) *retryTransport {
+	if next == nil {
+		next = http.DefaultTransport
+	}
+	return &retryTransport{next: next, maxElapsed: maxElapsed, maxAttempts: maxAttempts}
+}
+
+func (t *retryTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	start := time.Now()
+	backoff := 500 * time.Millisecond
+	const maxBackoff = 30 * time.Second
+
+	for attempt := 1; ; attempt++ {
+		resp, err := t.next.RoundTrip(req)
+
+		retry := isRetryableNetError(err) || (err == nil && retryableStatusCodes[resp.StatusCode])
+		if !retry {
+			return resp, err
+		}
+
+		if t.maxAttempts > 0 && attempt >= t.maxAttempts {
+			debug.Log("oci: giving up after %d attempts", attempt)
+			return resp, err
+		}
+		if t.maxElapsed > 0 && time.Since(start) >= t.maxElapsed {
+			debug.Log("oci: giving up after %v", time.Since(start))
+			return resp, err
+		}
+		if req.Body != nil && req.GetBody == nil {
+			// the body can't be replayed, so retrying would send a
+			// truncated or empty request; let the caller handle it.
+			return resp, err
+		}
+
+		wait := retryAfter(resp, backoff)
+		debug.Log("oci: retrying request after %v (attempt %d)", wait, attempt)
+
+		// we're committed to retrying and discarding resp: drain and close
+		// its body so the underlying connection can be reused, per the
+		// http.RoundTripper contract.
+		if resp != nil {
+			_, _ = io.Copy(io.Discard, resp.Body)
+			resp.Body.Close()
+		}
+
+		if req.GetBody != nil {
+			body, err := req.GetBody()
+			if err != nil {
+				return resp, err
+			}
+			req.Body = body
+		}
+
+		select {
+		case <-req.Context().Done():
+			return resp, req.Context().Err()
+		case <-time.After(wait):
+		}
+
+		backoff *= 2
+		if backoff > maxBackoff {
+			backoff = maxBackoff
+		}
+	}
+}
+
+// retryAfter returns the wait duration for the next attempt, honoring the
+// Retry-After header when the response provides one, and otherwise jittering
+// the given backoff by up to 20%.
+func retryAfter(resp *http.Response, backoff time.Duration) time.Duration {
+	if resp != nil {
+		if v := resp.Header.Get("Retry-After"); v != "" {
+			if secs, err := strconv.Atoi(v); err == nil {
+				return time.Duration(secs) * time.Second
+			}
+		}
+	}
+	jitter := time.Duration(rand.Int63n(int64(backoff) / 5))
+	return backoff + jitter
+}
+
+// isRetryableNetError reports whether err looks like a transient network
+// failure (e.g. a dial or read timeout) worth retrying.
+func isRetryableNetError(err error) bool {
+	if err == nil {
+		return false
+	}
+	var opErr *net.OpError
+	if ok := asOpError(err, &opErr); ok {
+		return opErr.Timeout() || opErr.Temporary()
+	}
+	return false
+}
+
+func asOpError(err error, target **net.OpError) bool {
+	for err != nil {
+		if opErr, ok := err.(*net.OpError); ok {
+			*target = opErr
+			return true
+		}
+		u, ok := err.(interface{ Unwrap() error })
+		if !ok {
+			return false
+		}
+		err = u.Unwrap()
+	}
+	return false
+}
+
+// maxUploadRestarts bounds how many times uploadStreamWithRetry restarts a
+// whole multipart upload from scratch. Each individual HTTP request within a
+// single UploadStream call is already retried by retryTransport, bounded by
+// the very same cfg.RetryMaxElapsed deadline this function installs on ctx -
+// so this is deliberately a small fixed cap rather than cfg.RetryMaxAttempts,
+// which would otherwise let the two layers multiply into a much longer
+// worst-case retry time than RetryMaxElapsed promises.
+const maxUploadRestarts = 3
+
+// uploadStreamWithRetry runs uploadManager.UploadStream, retrying on
+// throttling or transient 5xx responses from OCI. The request body comes
+// from rd, a restic.RewindReader, so it must be rewound via rd.Rewind()
+// before every retry - otherwise the retried upload would send zero bytes.
+//
+// ctx is given a single cfg.RetryMaxElapsed deadline up front, shared with
+// every part request retryTransport makes underneath UploadStream, so the
+// whole upload - across every part and every restart here - gives up within
+// that one budget instead of each layer getting its own.
+func (be *Backend) uploadStreamWithRetry(ctx context.Context, uploadManager *transfer.UploadManager, req transfer.UploadStreamRequest, rd restic.RewindReader) error {
+	if maxElapsed := be.cfg.RetryMaxElapsed; maxElapsed > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, maxElapsed)
+		defer cancel()
+	}
+
+	ociNamespace := SafeDeref[string](req.NamespaceName)
+	objName := SafeDeref[string](req.ObjectName)
+
+	backoff := 500 * time.Millisecond
+	const maxBackoff = 30 * time.Second
+
+	for attempt := 1; ; attempt++ {
+		if attempt > 1 {
+			if err := rd.Rewind(); err != nil {
+				return errors.Wrap(err, "Rewind")
+			}
+			req.StreamReader = io.NopCloser(rd)
+		}
+
+		_, err := uploadManager.UploadStream(ctx, req)
+		if err == nil {
+			return nil
+		}
+
+		// UploadStream is expected to abort its own multipart upload on
+		// failure, but make sure none is left dangling before we restart
+		// the whole upload from scratch under a fresh UploadId or give up.
+		// The cleanup runs on a context of its own, so it still happens
+		// when ctx was canceled or its deadline has passed.
+		abortCtx, abortCancel := context.WithTimeout(context.Background(), time.Minute)
+		be.abortStaleMultipartUploads(abortCtx, ociNamespace, objName)
+		abortCancel()
+
+		if !isRetryableOCIError(err) {
+			return err
+		}
+		if attempt >= maxUploadRestarts {
+			debug.Log("oci: giving up upload after %d attempts: %v", attempt, err)
+			return err
+		}
+
+		debug.Log("oci: retrying upload after %v (attempt %d): %v", backoff, attempt, err)
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(backoff):
+		}
+
+		backoff *= 2
+		if backoff > maxBackoff {
+			backoff = maxBackoff
+		}
+	}
+}
+
+// abortStaleMultipartUploads aborts any in-progress multipart upload left
+// behind for objName, so a retried Save doesn't accumulate orphaned
+// multipart uploads (which OCI otherwise keeps, and bills storage for,
+// until they're aborted or completed). Failures are logged and swallowed:
+// this is best-effort cleanup on an already-failed upload, not worth
+// failing the retry over.
+func (be *Backend) abortStaleMultipartUploads(ctx context.Context, ociNamespace, objName string) {
+	resp, err := be.client.ListMultipartUploads(ctx, objectstorage.ListMultipartUploadsRequest{
+		NamespaceName: common.String(ociNamespace),
+		BucketName:    common.String(be.cfg.BucketName),
+	})
+	if err != nil {
+		debug.Log("oci: listing multipart uploads for %v failed, continuing: %v", objName, err)
+		return
+	}
+
+	for _, u := range resp.Items {
+		if SafeDeref[string](u.Object) != objName {
+			continue
+		}
+		_, err := be.client.AbortMultipartUpload(ctx, objectstorage.AbortMultipartUploadRequest{
+			NamespaceName: common.String(ociNamespace),
+			BucketName:    common.String(be.cfg.BucketName),
+			ObjectName:    common.String(objName),
+			UploadId:      u.UploadId,
+		})
+		if err != nil {
+			debug.Log("oci: aborting stale multipart upload %v for %v failed: %v", SafeDeref[string](u.UploadId), objName, err)
+		}
+	}
+}
+
+// isRetryableOCIError reports whether err is a throttling or transient 5xx
+// response from the OCI service worth retrying.
+func isRetryableOCIError(err error) bool {
+	var svcErr common.ServiceError
+	if errors.As(err, &svcErr) {
+		return retryableStatusCodes[svcErr.GetHTTPStatusCode()]
+	}
+	return isRetryableNetError(err)
+}